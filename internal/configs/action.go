@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package configs
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty/gocty"
+
+	"github.com/hashicorp/terraform/internal/addrs"
+)
+
+// Action represents an "action" block in configuration, declaring an
+// invocable unit of provider-defined behavior that is not tied to
+// creating, updating, or destroying a particular resource.
+type Action struct {
+	Name string
+
+	// Config is the body of the action's "config" block, to be evaluated
+	// against the provider's action schema. It is nil for actions that
+	// take no configuration.
+	Config hcl.Body
+
+	ProviderConfigRef *ProviderConfigRef
+	Provider          addrs.Provider
+
+	// Lifecycle holds the decoded contents of the action's optional
+	// "lifecycle" block, or nil if none was given.
+	Lifecycle *ActionLifecycle
+
+	// Serial is shorthand for MaxParallelism = 1: at most one instance of
+	// this action may be invoked at a time. It is mutually exclusive with
+	// MaxParallelism.
+	Serial bool
+
+	// MaxParallelism bounds how many instances of a for_each/count action
+	// can be invoked concurrently. Zero means unbounded.
+	MaxParallelism int
+
+	DeclRange hcl.Range
+}
+
+var actionBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "provider"},
+		{Name: "count"},
+		{Name: "for_each"},
+		{Name: "max_parallelism"},
+		{Name: "serial"},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "config"},
+		{Type: "lifecycle"},
+	},
+}
+
+func decodeActionBlock(block *hcl.Block) (*Action, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	action := &Action{
+		Name:      block.Labels[1],
+		DeclRange: block.DefRange,
+	}
+
+	content, _, moreDiags := block.Body.PartialContent(actionBlockSchema)
+	diags = append(diags, moreDiags...)
+
+	for _, b := range content.Blocks {
+		switch b.Type {
+		case "config":
+			if action.Config != nil {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Duplicate config block",
+					Detail:   "An action block may have only one config block.",
+					Subject:  b.DefRange.Ptr(),
+				})
+				continue
+			}
+			action.Config = b.Body
+
+		case "lifecycle":
+			lifecycle, moreDiags := decodeActionLifecycleBlock(b)
+			diags = append(diags, moreDiags...)
+			action.Lifecycle = lifecycle
+		}
+	}
+
+	if attr, exists := content.Attributes["provider"]; exists {
+		ref, moreDiags := decodeProviderConfigRef(attr.Expr, "action")
+		diags = append(diags, moreDiags...)
+		action.ProviderConfigRef = ref
+	}
+
+	if attr, exists := content.Attributes["serial"]; exists {
+		val, valDiags := attr.Expr.Value(nil)
+		diags = append(diags, valDiags...)
+		if !valDiags.HasErrors() {
+			if err := gocty.FromCtyValue(val, &action.Serial); err != nil {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid serial value",
+					Detail:   fmt.Sprintf("The serial argument must be a bool: %s.", err),
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			}
+		}
+	}
+
+	if attr, exists := content.Attributes["max_parallelism"]; exists {
+		if action.Serial {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid max_parallelism value",
+				Detail:   "max_parallelism cannot be set when serial is true.",
+				Subject:  attr.Expr.Range().Ptr(),
+			})
+		} else {
+			val, valDiags := attr.Expr.Value(nil)
+			diags = append(diags, valDiags...)
+			if !valDiags.HasErrors() {
+				if err := gocty.FromCtyValue(val, &action.MaxParallelism); err != nil {
+					diags = append(diags, &hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  "Invalid max_parallelism value",
+						Detail:   fmt.Sprintf("The max_parallelism argument must be a whole number: %s.", err),
+						Subject:  attr.Expr.Range().Ptr(),
+					})
+				} else if action.MaxParallelism < 1 {
+					diags = append(diags, &hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  "Invalid max_parallelism value",
+						Detail:   "The max_parallelism argument must be at least 1.",
+						Subject:  attr.Expr.Range().Ptr(),
+					})
+					action.MaxParallelism = 0
+				}
+			}
+		}
+	}
+
+	return action, diags
+}
+
+// ProviderConfigAddr returns the address of the provider configuration
+// this action is declared to use, relative to its own module.
+func (a *Action) ProviderConfigAddr() addrs.LocalProviderConfig {
+	if a.ProviderConfigRef == nil {
+		return addrs.LocalProviderConfig{LocalName: a.Provider.Type}
+	}
+	return addrs.LocalProviderConfig{
+		LocalName: a.ProviderConfigRef.Name,
+		Alias:     a.ProviderConfigRef.Alias,
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package configs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty/gocty"
+)
+
+// ActionOnFailure controls what an action invocation node does once it has
+// exhausted its retry policy and still has not succeeded.
+type ActionOnFailure string
+
+const (
+	// ActionOnFailureFail aborts the plan/apply with an error. This is the
+	// default when no lifecycle block is given.
+	ActionOnFailureFail ActionOnFailure = "fail"
+
+	// ActionOnFailureContinue records the failure as a warning and lets the
+	// rest of the graph walk proceed.
+	ActionOnFailureContinue ActionOnFailure = "continue"
+
+	// ActionOnFailureIgnore discards the failure entirely and records the
+	// invocation as if it had not been attempted.
+	ActionOnFailureIgnore ActionOnFailure = "ignore"
+)
+
+// ActionRetryBackoff selects the strategy used to space out retry attempts.
+type ActionRetryBackoff string
+
+const (
+	// ActionRetryBackoffFixed waits MinInterval between every attempt.
+	ActionRetryBackoffFixed ActionRetryBackoff = "fixed"
+
+	// ActionRetryBackoffExponential doubles the wait on each attempt,
+	// bounded by MaxInterval.
+	ActionRetryBackoffExponential ActionRetryBackoff = "exponential"
+)
+
+// ActionRetry is the decoded representation of an action's `retry` block.
+type ActionRetry struct {
+	Attempts    int
+	MinInterval time.Duration
+	MaxInterval time.Duration
+	Backoff     ActionRetryBackoff
+}
+
+// ActionLifecycle is the decoded representation of an action's `lifecycle`
+// block, governing how Terraform invokes the action and how it reacts to
+// failures.
+type ActionLifecycle struct {
+	// Timeout bounds how long an invocation, including all of its retry
+	// attempts, is allowed to run before Terraform stops waiting for it and
+	// reports it as timed out. A provider call already in flight is not
+	// aborted.
+	Timeout *time.Duration
+
+	Retry *ActionRetry
+
+	// OnFailure defaults to ActionOnFailureFail when unset.
+	OnFailure ActionOnFailure
+}
+
+var actionLifecycleBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "timeout"},
+		{Name: "on_failure"},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "retry"},
+	},
+}
+
+var actionRetryBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "attempts"},
+		{Name: "min_interval"},
+		{Name: "max_interval"},
+		{Name: "backoff"},
+	},
+}
+
+func decodeActionLifecycleBlock(block *hcl.Block) (*ActionLifecycle, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	lifecycle := &ActionLifecycle{OnFailure: ActionOnFailureFail}
+
+	content, moreDiags := block.Body.Content(actionLifecycleBlockSchema)
+	diags = append(diags, moreDiags...)
+
+	if attr, exists := content.Attributes["timeout"]; exists {
+		d, moreDiags := decodeActionDurationAttr(attr)
+		diags = append(diags, moreDiags...)
+		lifecycle.Timeout = d
+	}
+
+	if attr, exists := content.Attributes["on_failure"]; exists {
+		val, valDiags := attr.Expr.Value(nil)
+		diags = append(diags, valDiags...)
+		if !valDiags.HasErrors() {
+			switch onFailure := ActionOnFailure(val.AsString()); onFailure {
+			case ActionOnFailureFail, ActionOnFailureContinue, ActionOnFailureIgnore:
+				lifecycle.OnFailure = onFailure
+			default:
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid on_failure value",
+					Detail:   `The on_failure argument must be one of "fail", "continue", or "ignore".`,
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			}
+		}
+	}
+
+	for _, b := range content.Blocks {
+		if b.Type != "retry" {
+			continue
+		}
+		retry, moreDiags := decodeActionRetryBlock(b)
+		diags = append(diags, moreDiags...)
+		lifecycle.Retry = retry
+	}
+
+	return lifecycle, diags
+}
+
+func decodeActionRetryBlock(block *hcl.Block) (*ActionRetry, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	retry := &ActionRetry{
+		Attempts: 1,
+		Backoff:  ActionRetryBackoffFixed,
+	}
+
+	content, moreDiags := block.Body.Content(actionRetryBlockSchema)
+	diags = append(diags, moreDiags...)
+
+	if attr, exists := content.Attributes["attempts"]; exists {
+		val, valDiags := attr.Expr.Value(nil)
+		diags = append(diags, valDiags...)
+		if !valDiags.HasErrors() {
+			if err := gocty.FromCtyValue(val, &retry.Attempts); err != nil {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid attempts value",
+					Detail:   fmt.Sprintf("The attempts argument must be a whole number: %s.", err),
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			} else if retry.Attempts < 1 {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid attempts value",
+					Detail:   "The attempts argument must be at least 1.",
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+				retry.Attempts = 1
+			}
+		}
+	}
+
+	if attr, exists := content.Attributes["min_interval"]; exists {
+		d, moreDiags := decodeActionDurationAttr(attr)
+		diags = append(diags, moreDiags...)
+		if d != nil {
+			retry.MinInterval = *d
+		}
+	}
+
+	if attr, exists := content.Attributes["max_interval"]; exists {
+		d, moreDiags := decodeActionDurationAttr(attr)
+		diags = append(diags, moreDiags...)
+		if d != nil {
+			retry.MaxInterval = *d
+		}
+	}
+
+	if attr, exists := content.Attributes["backoff"]; exists {
+		val, valDiags := attr.Expr.Value(nil)
+		diags = append(diags, valDiags...)
+		if !valDiags.HasErrors() {
+			switch backoff := ActionRetryBackoff(val.AsString()); backoff {
+			case ActionRetryBackoffFixed, ActionRetryBackoffExponential:
+				retry.Backoff = backoff
+			default:
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid backoff value",
+					Detail:   `The backoff argument must be either "fixed" or "exponential".`,
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			}
+		}
+	}
+
+	return retry, diags
+}
+
+// decodeActionDurationAttr decodes an attribute whose value is a duration
+// string like "30s" or "5m", as accepted by time.ParseDuration.
+func decodeActionDurationAttr(attr *hcl.Attribute) (*time.Duration, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	val, valDiags := attr.Expr.Value(nil)
+	diags = append(diags, valDiags...)
+	if valDiags.HasErrors() {
+		return nil, diags
+	}
+
+	d, err := time.ParseDuration(val.AsString())
+	if err != nil {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid duration",
+			Detail:   fmt.Sprintf("This must be a duration string like \"30s\" or \"5m\": %s.", err),
+			Subject:  attr.Expr.Range().Ptr(),
+		})
+		return nil, diags
+	}
+	return &d, diags
+}
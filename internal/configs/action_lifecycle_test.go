@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package configs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+)
+
+func parseTestLifecycleBlock(t *testing.T, src string) *hcl.Block {
+	t.Helper()
+
+	parser := hclparse.NewParser()
+	f, diags := parser.ParseHCL([]byte(src), "test.tf")
+	if diags.HasErrors() {
+		t.Fatalf("failed to parse test fixture: %s", diags)
+	}
+
+	content, diags := f.Body.Content(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "lifecycle"}},
+	})
+	if diags.HasErrors() {
+		t.Fatalf("failed to find lifecycle block: %s", diags)
+	}
+	if len(content.Blocks) != 1 {
+		t.Fatalf("expected exactly one lifecycle block, got %d", len(content.Blocks))
+	}
+	return content.Blocks[0]
+}
+
+func TestDecodeActionLifecycleBlock(t *testing.T) {
+	block := parseTestLifecycleBlock(t, `
+lifecycle {
+  timeout    = "30s"
+  on_failure = "continue"
+
+  retry {
+    attempts     = 3
+    min_interval = "1s"
+    max_interval = "10s"
+    backoff      = "exponential"
+  }
+}
+`)
+
+	lifecycle, diags := decodeActionLifecycleBlock(block)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags)
+	}
+
+	if lifecycle.Timeout == nil || *lifecycle.Timeout != 30*time.Second {
+		t.Errorf("wrong timeout: %v", lifecycle.Timeout)
+	}
+	if lifecycle.OnFailure != ActionOnFailureContinue {
+		t.Errorf("wrong on_failure: %s", lifecycle.OnFailure)
+	}
+	if lifecycle.Retry == nil {
+		t.Fatal("expected a retry policy")
+	}
+	if lifecycle.Retry.Attempts != 3 {
+		t.Errorf("wrong attempts: %d", lifecycle.Retry.Attempts)
+	}
+	if lifecycle.Retry.MinInterval != 1*time.Second {
+		t.Errorf("wrong min_interval: %s", lifecycle.Retry.MinInterval)
+	}
+	if lifecycle.Retry.MaxInterval != 10*time.Second {
+		t.Errorf("wrong max_interval: %s", lifecycle.Retry.MaxInterval)
+	}
+	if lifecycle.Retry.Backoff != ActionRetryBackoffExponential {
+		t.Errorf("wrong backoff: %s", lifecycle.Retry.Backoff)
+	}
+}
+
+func TestDecodeActionLifecycleBlock_invalidAttempts(t *testing.T) {
+	block := parseTestLifecycleBlock(t, `
+lifecycle {
+  retry {
+    attempts = 0
+  }
+}
+`)
+
+	lifecycle, diags := decodeActionLifecycleBlock(block)
+	if !diags.HasErrors() {
+		t.Fatal("expected an error for attempts = 0")
+	}
+	if lifecycle.Retry.Attempts != 1 {
+		t.Errorf("expected attempts to be clamped to 1 after a decode error, got %d", lifecycle.Retry.Attempts)
+	}
+}
+
+func TestDecodeActionLifecycleBlock_invalidOnFailure(t *testing.T) {
+	block := parseTestLifecycleBlock(t, `
+lifecycle {
+  on_failure = "retry-forever"
+}
+`)
+
+	_, diags := decodeActionLifecycleBlock(block)
+	if !diags.HasErrors() {
+		t.Fatal("expected an error for an invalid on_failure value")
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package configs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+)
+
+func parseTestActionBlock(t *testing.T, src string) *hcl.Block {
+	t.Helper()
+
+	parser := hclparse.NewParser()
+	f, diags := parser.ParseHCL([]byte(src), "test.tf")
+	if diags.HasErrors() {
+		t.Fatalf("failed to parse test fixture: %s", diags)
+	}
+
+	content, diags := f.Body.Content(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "action", LabelNames: []string{"type", "name"}}},
+	})
+	if diags.HasErrors() {
+		t.Fatalf("failed to find action block: %s", diags)
+	}
+	if len(content.Blocks) != 1 {
+		t.Fatalf("expected exactly one action block, got %d", len(content.Blocks))
+	}
+	return content.Blocks[0]
+}
+
+func TestDecodeActionBlock_serial(t *testing.T) {
+	block := parseTestActionBlock(t, `
+action "test" "example" {
+  serial = true
+}
+`)
+
+	action, diags := decodeActionBlock(block)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags)
+	}
+	if !action.Serial {
+		t.Error("expected Serial to be true")
+	}
+	if action.MaxParallelism != 0 {
+		t.Errorf("expected MaxParallelism to be unset, got %d", action.MaxParallelism)
+	}
+}
+
+func TestDecodeActionBlock_maxParallelism(t *testing.T) {
+	block := parseTestActionBlock(t, `
+action "test" "example" {
+  max_parallelism = 5
+}
+`)
+
+	action, diags := decodeActionBlock(block)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags)
+	}
+	if action.MaxParallelism != 5 {
+		t.Errorf("expected MaxParallelism to be 5, got %d", action.MaxParallelism)
+	}
+}
+
+func TestDecodeActionBlock_serialAndMaxParallelismConflict(t *testing.T) {
+	block := parseTestActionBlock(t, `
+action "test" "example" {
+  serial          = true
+  max_parallelism = 5
+}
+`)
+
+	_, diags := decodeActionBlock(block)
+	if !diags.HasErrors() {
+		t.Fatal("expected an error when both serial and max_parallelism are set")
+	}
+}
+
+func TestDecodeActionBlock_invalidMaxParallelism(t *testing.T) {
+	block := parseTestActionBlock(t, `
+action "test" "example" {
+  max_parallelism = 0
+}
+`)
+
+	action, diags := decodeActionBlock(block)
+	if !diags.HasErrors() {
+		t.Fatal("expected an error for max_parallelism = 0")
+	}
+	if action.MaxParallelism != 0 {
+		t.Errorf("expected MaxParallelism to remain 0 after a decode error, got %d", action.MaxParallelism)
+	}
+}
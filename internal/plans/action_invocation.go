@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package plans
+
+import (
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform/internal/addrs"
+)
+
+// ActionTrigger describes what caused an action invocation to be recorded
+// in a plan.
+type ActionTrigger interface {
+	actionTrigger()
+}
+
+// InvokeActionTrigger indicates that an action invocation was triggered
+// directly, by targeting the action with the -invoke CLI argument, rather
+// than by a resource's lifecycle action_trigger configuration.
+type InvokeActionTrigger struct{}
+
+func (*InvokeActionTrigger) actionTrigger() {}
+
+// ActionInvocationInstance describes a single planned invocation of an
+// action.
+type ActionInvocationInstance struct {
+	Addr          addrs.AbsActionInstance
+	ActionTrigger ActionTrigger
+	ProviderAddr  addrs.AbsProviderConfig
+	ConfigValue   cty.Value
+
+	// RetryStatus records how many attempts this invocation took and how it
+	// ultimately concluded. It is nil for invocations that were deferred,
+	// whether because of unknown configuration before any attempt was made
+	// or because the provider deferred them.
+	RetryStatus *ActionInvocationRetryStatus
+
+	// EffectiveParallelism is the concurrency bound that was in effect for
+	// this instance's action (0 meaning unbounded), recorded here purely
+	// for operator observability.
+	EffectiveParallelism int
+}
@@ -0,0 +1,22 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package plans
+
+// ActionInvocationOutcome is the terminal status of an action invocation
+// that was governed by a retry policy.
+type ActionInvocationOutcome string
+
+const (
+	ActionInvocationOutcomeSucceeded ActionInvocationOutcome = "succeeded"
+	ActionInvocationOutcomeFailed    ActionInvocationOutcome = "failed"
+	ActionInvocationOutcomeIgnored   ActionInvocationOutcome = "ignored"
+)
+
+// ActionInvocationRetryStatus records how many attempts an action invocation
+// took and how it ultimately concluded, so that operators can see what
+// happened without scraping logs.
+type ActionInvocationRetryStatus struct {
+	Attempts int
+	Outcome  ActionInvocationOutcome
+}
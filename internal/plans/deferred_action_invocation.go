@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package plans
+
+// DeferredActionInvocationReason describes why an action invocation could
+// not be carried out during the current plan/apply round.
+type DeferredActionInvocationReason string
+
+const (
+	// DeferredActionInvocationReasonUnknown is used when the action's
+	// configuration contains unknown values, meaning the resources it
+	// references have not yet been created or updated.
+	DeferredActionInvocationReasonUnknown DeferredActionInvocationReason = "unknown"
+
+	// DeferredActionInvocationReasonDeferredProvider is used when the
+	// provider itself signalled, via the Deferred field of its response,
+	// that the action invocation must be deferred to a later round.
+	DeferredActionInvocationReasonDeferredProvider DeferredActionInvocationReason = "provider_config"
+)
+
+// DeferredActionInvocation represents an action invocation that could not
+// be completed during this plan/apply round and must be reattempted once
+// the condition that caused the deferral no longer applies, mirroring how
+// DeferredResourceChange tracks resource changes that had to be deferred.
+type DeferredActionInvocation struct {
+	// ActionInvocation is the invocation that was deferred. Its ConfigValue
+	// may still contain unknown values.
+	ActionInvocation *ActionInvocationInstance
+
+	// Reason explains why the action invocation could not be carried out.
+	Reason DeferredActionInvocationReason
+}
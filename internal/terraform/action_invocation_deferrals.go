@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package terraform
+
+import (
+	"github.com/hashicorp/terraform/internal/plans"
+)
+
+// actionInvocationDeferrals tracks action invocations deferred during a
+// -invoke walk. It is populated from the -invoke-allow-deferred CLI
+// argument and collects every invocation that had to be deferred so that
+// the invoke command can list them in its plan output, and so a later
+// -invoke walk (once the deferred invocations' references are known) can
+// find and retry them.
+type actionInvocationDeferrals struct {
+	allowed     bool
+	invocations []*plans.DeferredActionInvocation
+}
+
+// newActionInvocationDeferrals constructs a deferral tracker. allowed
+// should reflect whether the user passed -invoke-allow-deferred.
+func newActionInvocationDeferrals(allowed bool) *actionInvocationDeferrals {
+	return &actionInvocationDeferrals{allowed: allowed}
+}
+
+// DeferralAllowed reports whether the current -invoke walk is allowed to
+// defer action invocations rather than erroring on them.
+func (d *actionInvocationDeferrals) DeferralAllowed() bool {
+	if d == nil {
+		return false
+	}
+	return d.allowed
+}
+
+// ReportActionInvocationDeferred records that ai could not be carried out
+// for the given reason. It is a no-op if deferral isn't allowed, since
+// callers are expected to check DeferralAllowed before invoking an action
+// that needs to be deferred.
+func (d *actionInvocationDeferrals) ReportActionInvocationDeferred(ai *plans.ActionInvocationInstance, reason plans.DeferredActionInvocationReason) {
+	if d == nil || !d.allowed {
+		return
+	}
+	d.invocations = append(d.invocations, &plans.DeferredActionInvocation{
+		ActionInvocation: ai,
+		Reason:           reason,
+	})
+}
+
+// DeferredActionInvocations returns every action invocation that was
+// deferred during this walk, in the order they were reported, for the
+// invoke command to surface in its plan output and reattempt on a later
+// walk.
+func (d *actionInvocationDeferrals) DeferredActionInvocations() []*plans.DeferredActionInvocation {
+	if d == nil {
+		return nil
+	}
+	return d.invocations
+}
+
+// EvalContextActionDeferrals is an optional extension of EvalContext,
+// implemented only by the context used for -invoke graph walks, that
+// exposes the action invocation deferral tracker for the current walk. It
+// is kept separate from EvalContext itself, rather than adding a Deferrals
+// method there directly, for the same reason ActionInvocationHook is kept
+// separate from Hook: every other kind of walk would otherwise need a
+// meaningless implementation of it.
+type EvalContextActionDeferrals interface {
+	ActionInvocationDeferrals() *actionInvocationDeferrals
+}
+
+// actionInvocationDeferralsFor returns the deferral tracker for ctx. If ctx
+// doesn't implement EvalContextActionDeferrals -- e.g. because it belongs
+// to a walk other than -invoke -- it returns a nil tracker, which reports
+// deferral as disallowed and silently discards any report.
+func actionInvocationDeferralsFor(ctx EvalContext) *actionInvocationDeferrals {
+	if d, ok := ctx.(EvalContextActionDeferrals); ok {
+		return d.ActionInvocationDeferrals()
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package terraform
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/internal/plans"
+)
+
+func TestActionInvocationDeferrals_disallowed(t *testing.T) {
+	d := newActionInvocationDeferrals(false)
+
+	if d.DeferralAllowed() {
+		t.Fatal("expected deferral to not be allowed")
+	}
+
+	d.ReportActionInvocationDeferred(&plans.ActionInvocationInstance{}, plans.DeferredActionInvocationReasonUnknown)
+
+	if got := d.DeferredActionInvocations(); len(got) != 0 {
+		t.Fatalf("expected no deferred invocations to be recorded when deferral isn't allowed, got %d", len(got))
+	}
+}
+
+func TestActionInvocationDeferrals_allowed(t *testing.T) {
+	d := newActionInvocationDeferrals(true)
+
+	if !d.DeferralAllowed() {
+		t.Fatal("expected deferral to be allowed")
+	}
+
+	first := &plans.ActionInvocationInstance{}
+	second := &plans.ActionInvocationInstance{}
+	d.ReportActionInvocationDeferred(first, plans.DeferredActionInvocationReasonUnknown)
+	d.ReportActionInvocationDeferred(second, plans.DeferredActionInvocationReasonDeferredProvider)
+
+	got := d.DeferredActionInvocations()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 deferred invocations, got %d", len(got))
+	}
+	if got[0].ActionInvocation != first || got[0].Reason != plans.DeferredActionInvocationReasonUnknown {
+		t.Errorf("wrong first entry: %#v", got[0])
+	}
+	if got[1].ActionInvocation != second || got[1].Reason != plans.DeferredActionInvocationReasonDeferredProvider {
+		t.Errorf("wrong second entry: %#v", got[1])
+	}
+}
+
+func TestActionInvocationDeferrals_nil(t *testing.T) {
+	var d *actionInvocationDeferrals
+
+	if d.DeferralAllowed() {
+		t.Fatal("nil tracker should report deferral as not allowed")
+	}
+	if got := d.DeferredActionInvocations(); got != nil {
+		t.Fatalf("nil tracker should report no deferred invocations, got %v", got)
+	}
+
+	// Must not panic.
+	d.ReportActionInvocationDeferred(&plans.ActionInvocationInstance{}, plans.DeferredActionInvocationReasonUnknown)
+}
@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package terraform
+
+// actionInvocationSemaphore bounds how many instances of a single expanded
+// action can have their provider invocation in flight at the same time. A
+// nil *actionInvocationSemaphore imposes no limit beyond whatever
+// parallelism the graph walker itself allows.
+type actionInvocationSemaphore chan struct{}
+
+// newActionInvocationSemaphore returns a semaphore capping concurrency at
+// max, or nil if max is non-positive (meaning "no action-specific limit").
+func newActionInvocationSemaphore(max int) *actionInvocationSemaphore {
+	if max <= 0 {
+		return nil
+	}
+	sem := make(actionInvocationSemaphore, max)
+	return &sem
+}
+
+func (s *actionInvocationSemaphore) acquire() {
+	if s == nil {
+		return
+	}
+	*s <- struct{}{}
+}
+
+func (s *actionInvocationSemaphore) release() {
+	if s == nil {
+		return
+	}
+	<-*s
+}
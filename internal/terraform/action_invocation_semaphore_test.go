@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package terraform
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewActionInvocationSemaphore_unbounded(t *testing.T) {
+	if sem := newActionInvocationSemaphore(0); sem != nil {
+		t.Fatalf("expected a non-positive max to produce a nil (unbounded) semaphore, got %v", sem)
+	}
+
+	var sem *actionInvocationSemaphore
+	// Must not block or panic when nil, representing "no action-specific
+	// limit" (e.g. neither serial nor max_parallelism was set).
+	sem.acquire()
+	sem.release()
+}
+
+func TestActionInvocationSemaphore_boundsConcurrency(t *testing.T) {
+	const limit = 3
+	sem := newActionInvocationSemaphore(limit)
+
+	var (
+		current int32
+		maxSeen int32
+		wg      sync.WaitGroup
+	)
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem.acquire()
+			defer sem.release()
+
+			n := atomic.AddInt32(&current, 1)
+			for {
+				prev := atomic.LoadInt32(&maxSeen)
+				if n <= prev || atomic.CompareAndSwapInt32(&maxSeen, prev, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&current, -1)
+		}()
+	}
+	wg.Wait()
+
+	if maxSeen > limit {
+		t.Fatalf("observed %d concurrent holders, want at most %d", maxSeen, limit)
+	}
+}
+
+func TestActionInvocationSemaphore_serialIsMutualExclusion(t *testing.T) {
+	sem := newActionInvocationSemaphore(1)
+
+	sem.acquire()
+	acquired := make(chan struct{})
+	go func() {
+		sem.acquire()
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("second acquire should have blocked while the first holder was still active")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	sem.release()
+
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("second acquire should have unblocked after release")
+	}
+	sem.release()
+}
@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package terraform
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform/internal/addrs"
+	"github.com/hashicorp/terraform/internal/plans"
+	"github.com/hashicorp/terraform/internal/providers"
+)
+
+// ActionInvocationProgress is a single structured status update emitted by
+// a provider while it is carrying out an action invocation, for example a
+// human-readable status line describing what the action is currently
+// doing.
+type ActionInvocationProgress struct {
+	Message string
+}
+
+// ActionInvocationHook is an optional extension of Hook that a Hook
+// implementation can satisfy in order to receive lifecycle events for
+// action invocations triggered by the -invoke CLI flag. It is kept
+// separate from Hook itself so that existing Hook implementations don't
+// all need matching no-op implementations of these methods.
+type ActionInvocationHook interface {
+	// PreInvokeAction is called immediately before an action invocation is
+	// sent to the provider.
+	PreInvokeAction(addr addrs.AbsActionInstance, schema *providers.ActionSchema) (HookAction, error)
+
+	// ProgressInvokeAction is called for each progress update while an
+	// action invocation is in flight. providers.PlanActionRequest/Response
+	// is a single synchronous call rather than a stream, so today these
+	// updates are synthesized by the retry loop itself (e.g. reporting a
+	// retry or a provider-ordered deferral); surfacing the provider's own
+	// streaming progress would require a streaming PlanAction RPC, which is
+	// a larger protocol change than this hook.
+	ProgressInvokeAction(addr addrs.AbsActionInstance, schema *providers.ActionSchema, elapsed time.Duration, progress ActionInvocationProgress) (HookAction, error)
+
+	// PostInvokeAction is called once an action invocation has completed
+	// successfully.
+	PostInvokeAction(addr addrs.AbsActionInstance, schema *providers.ActionSchema, invocation *plans.ActionInvocationInstance) (HookAction, error)
+
+	// ErrorInvokeAction is called when an action invocation could not be
+	// completed, either because the provider returned an error or because
+	// Terraform could not carry out the invocation at all. It is not called
+	// for a failure discarded by on_failure = "ignore".
+	ErrorInvokeAction(addr addrs.AbsActionInstance, schema *providers.ActionSchema, err error) (HookAction, error)
+}
+
+// hookActionInvoke calls fn against ctx's hooks, but only for hooks that
+// implement ActionInvocationHook, treating hooks that don't as a no-op.
+func hookActionInvoke(ctx EvalContext, fn func(ActionInvocationHook) (HookAction, error)) error {
+	return ctx.Hook(func(h Hook) (HookAction, error) {
+		ah, ok := h.(ActionInvocationHook)
+		if !ok {
+			return HookActionContinue, nil
+		}
+		return fn(ah)
+	})
+}
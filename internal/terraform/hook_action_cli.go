@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package terraform
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hashicorp/terraform/internal/addrs"
+	"github.com/hashicorp/terraform/internal/plans"
+	"github.com/hashicorp/terraform/internal/providers"
+)
+
+// ActionInvocationCLIHook is a basic ActionInvocationHook that renders a
+// human-readable progress line per action invocation lifecycle event. It
+// gives -invoke a default, readable UI; a JSON view can implement
+// ActionInvocationHook the same way to emit structured events instead.
+//
+// Command-layer code constructing the ContextOpts.Hooks for a -invoke run
+// is responsible for appending an *ActionInvocationCLIHook to it; this
+// package only defines the hook, it does not register one by default.
+type ActionInvocationCLIHook struct {
+	Writer io.Writer
+}
+
+var _ ActionInvocationHook = (*ActionInvocationCLIHook)(nil)
+
+func (h *ActionInvocationCLIHook) PreInvokeAction(addr addrs.AbsActionInstance, schema *providers.ActionSchema) (HookAction, error) {
+	fmt.Fprintf(h.Writer, "%s: Invoking...\n", addr)
+	return HookActionContinue, nil
+}
+
+func (h *ActionInvocationCLIHook) ProgressInvokeAction(addr addrs.AbsActionInstance, schema *providers.ActionSchema, elapsed time.Duration, progress ActionInvocationProgress) (HookAction, error) {
+	fmt.Fprintf(h.Writer, "%s: %s (%s elapsed)\n", addr, progress.Message, elapsed.Round(time.Second))
+	return HookActionContinue, nil
+}
+
+func (h *ActionInvocationCLIHook) PostInvokeAction(addr addrs.AbsActionInstance, schema *providers.ActionSchema, invocation *plans.ActionInvocationInstance) (HookAction, error) {
+	fmt.Fprintf(h.Writer, "%s: Invocation complete\n", addr)
+	return HookActionContinue, nil
+}
+
+func (h *ActionInvocationCLIHook) ErrorInvokeAction(addr addrs.AbsActionInstance, schema *providers.ActionSchema, err error) (HookAction, error) {
+	fmt.Fprintf(h.Writer, "%s: Invocation failed: %s\n", addr, err)
+	return HookActionContinue, nil
+}
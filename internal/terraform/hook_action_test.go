@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package terraform
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/internal/addrs"
+	"github.com/hashicorp/terraform/internal/providers"
+)
+
+func TestActionInvocationCLIHook(t *testing.T) {
+	var buf bytes.Buffer
+	hook := &ActionInvocationCLIHook{Writer: &buf}
+
+	var addr addrs.AbsActionInstance
+	var schema *providers.ActionSchema
+
+	if _, err := hook.PreInvokeAction(addr, schema); err != nil {
+		t.Fatalf("PreInvokeAction returned error: %s", err)
+	}
+	if _, err := hook.ProgressInvokeAction(addr, schema, 2*time.Second, ActionInvocationProgress{Message: "working"}); err != nil {
+		t.Fatalf("ProgressInvokeAction returned error: %s", err)
+	}
+	if _, err := hook.PostInvokeAction(addr, schema, nil); err != nil {
+		t.Fatalf("PostInvokeAction returned error: %s", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"Invoking...", "working", "Invocation complete"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+// nonActionHook is a minimal Hook implementation that does not also
+// implement ActionInvocationHook, to verify that hookActionInvoke skips it
+// rather than panicking or erroring.
+type nonActionHook struct {
+	NilHook
+}
+
+func TestHookActionInvoke_skipsNonActionHooks(t *testing.T) {
+	ctx := new(MockEvalContext)
+	ctx.HookHook = &nonActionHook{}
+
+	called := false
+	err := hookActionInvoke(ctx, func(h ActionInvocationHook) (HookAction, error) {
+		called = true
+		return HookActionContinue, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if called {
+		t.Error("expected hookActionInvoke not to call fn for a hook that doesn't implement ActionInvocationHook")
+	}
+}
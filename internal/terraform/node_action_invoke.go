@@ -4,7 +4,10 @@
 package terraform
 
 import (
+	"context"
 	"fmt"
+	"math/rand"
+	"time"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/zclconf/go-cty/cty"
@@ -24,6 +27,13 @@ type nodeActionInvokeAbstract struct {
 	Config           *configs.Action
 	resolvedProvider addrs.AbsProviderConfig // set during the graph walk
 	Schema           *providers.ActionSchema
+
+	// concurrency is shared by every nodeActionInvokePlanInstance expanded
+	// from the same action, so that max_parallelism/serial bounds how many
+	// of that action's instances can be invoked at once regardless of how
+	// the wider graph walk schedules them. It is set once in
+	// nodeActionInvokeExpand.DynamicExpand.
+	concurrency *actionInvocationSemaphore
 }
 
 var (
@@ -132,6 +142,15 @@ func (n *nodeActionInvokeExpand) DynamicExpand(context EvalContext) (*Graph, tfd
 			fmt.Sprintf("Action %s does not exist within the configuration.", n.Target.String())))
 	}
 
+	maxParallelism := 0 // 0 means unbounded: governed only by the graph walker.
+	switch {
+	case n.Config.Serial:
+		maxParallelism = 1
+	case n.Config.MaxParallelism > 0:
+		maxParallelism = n.Config.MaxParallelism
+	}
+	n.concurrency = newActionInvocationSemaphore(maxParallelism)
+
 	allInsts := context.InstanceExpander().AllInstances()
 	var g Graph
 	switch addr := n.Target.(type) {
@@ -204,11 +223,17 @@ func (n *nodeActionInvokePlanInstance) Execute(ctx EvalContext, _ walkOperation)
 		}
 	}
 
+	effectiveParallelism := 0 // 0 means unbounded
+	if n.concurrency != nil {
+		effectiveParallelism = cap(*n.concurrency)
+	}
+
 	ai := plans.ActionInvocationInstance{
-		Addr:          n.ActionTarget,
-		ActionTrigger: new(plans.InvokeActionTrigger),
-		ProviderAddr:  n.resolvedProvider,
-		ConfigValue:   ephemeral.RemoveEphemeralValues(configVal),
+		Addr:                 n.ActionTarget,
+		ActionTrigger:        new(plans.InvokeActionTrigger),
+		ProviderAddr:         n.resolvedProvider,
+		ConfigValue:          ephemeral.RemoveEphemeralValues(configVal),
+		EffectiveParallelism: effectiveParallelism,
 	}
 
 	provider, _, err := getProvider(ctx, n.resolvedProvider)
@@ -228,30 +253,209 @@ func (n *nodeActionInvokePlanInstance) Execute(ctx EvalContext, _ walkOperation)
 		// configuration. if the configuration of the action has unknown values
 		// it means one of the resources that are referenced hasn't actually
 		// been created.
-		return diags.Append(&hcl.Diagnostic{
-			Severity: hcl.DiagError,
-			Summary:  "Partially applied configuration",
-			Detail:   fmt.Sprintf("The action %s contains unknown values while planning. This means it is referencing resources that have not yet been created, please run a complete plan/apply cycle to ensure the state matches the configuration before using the -invoke argument.", n.Target.String()),
-			Subject:  n.Config.DeclRange.Ptr(),
+		deferrals := actionInvocationDeferralsFor(ctx)
+		if !deferrals.DeferralAllowed() {
+			return diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Partially applied configuration",
+				Detail:   fmt.Sprintf("The action %s contains unknown values while planning. This means it is referencing resources that have not yet been created, please run a complete plan/apply cycle to ensure the state matches the configuration before using the -invoke argument, or pass -invoke-allow-deferred to retry this action invocation once its configuration is fully known.", n.Target.String()),
+				Subject:  n.Config.DeclRange.Ptr(),
+			})
+		}
+
+		deferrals.ReportActionInvocationDeferred(&ai, plans.DeferredActionInvocationReasonUnknown)
+		return diags
+	}
+
+	invokeCtx := ctx.StopCtx()
+	onFailure := configs.ActionOnFailureFail
+	attempts, minInterval, maxInterval, backoff := 1, time.Duration(0), time.Duration(0), configs.ActionRetryBackoffFixed
+	if lifecycle := n.Config.Lifecycle; lifecycle != nil {
+		if lifecycle.OnFailure != "" {
+			onFailure = lifecycle.OnFailure
+		}
+		if lifecycle.Timeout != nil {
+			var cancel context.CancelFunc
+			invokeCtx, cancel = context.WithTimeout(invokeCtx, *lifecycle.Timeout)
+			defer cancel()
+		}
+		if retry := lifecycle.Retry; retry != nil {
+			attempts, minInterval, maxInterval, backoff = retry.Attempts, retry.MinInterval, retry.MaxInterval, retry.Backoff
+		}
+	}
+	if attempts < 1 {
+		// configs.decodeActionRetryBlock already rejects attempts < 1, but
+		// we clamp here too so a zero-value ActionRetry (e.g. constructed
+		// outside of config decoding) can never skip invoking the action
+		// altogether.
+		attempts = 1
+	}
+
+	n.concurrency.acquire()
+	defer n.concurrency.release()
+
+	if err := hookActionInvoke(ctx, func(h ActionInvocationHook) (HookAction, error) {
+		return h.PreInvokeAction(n.ActionTarget, n.Schema)
+	}); err != nil {
+		return diags.Append(err)
+	}
+
+	start := time.Now()
+	var resp providers.PlanActionResponse
+	var attempt int
+	for attempt = 1; attempt <= attempts; attempt++ {
+		if err := invokeCtx.Err(); err != nil {
+			attempt-- // this attempt was never made
+			resp.Diagnostics = resp.Diagnostics.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Action invocation timed out",
+				fmt.Sprintf("The invocation of %s did not complete within its configured timeout: %s.", n.Target, err),
+			))
+			break
+		}
+
+		// provider.PlanAction has no context parameter of its own, so
+		// Terraform cannot forcibly abort a provider call that is already
+		// in flight. Running it on its own goroutine and racing it against
+		// invokeCtx at least keeps a hung call from blocking the rest of
+		// the graph walk past the configured timeout, the same way the
+		// inter-attempt backoff sleep below is already interruptible.
+		attemptReq := providers.PlanActionRequest{
+			ActionType:         n.ActionTarget.Action.Action.Type,
+			ProposedActionData: unmarkedConfig,
+			ClientCapabilities: ctx.ClientCapabilities(),
+		}
+		respCh := make(chan providers.PlanActionResponse, 1)
+		go func() {
+			respCh <- provider.PlanAction(attemptReq)
+		}()
+
+		select {
+		case resp = <-respCh:
+		case <-invokeCtx.Done():
+			resp = providers.PlanActionResponse{}
+			resp.Diagnostics = resp.Diagnostics.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Action invocation timed out",
+				fmt.Sprintf("The invocation of %s did not complete within its configured timeout: %s.", n.Target, invokeCtx.Err()),
+			))
+		}
+		if !resp.Diagnostics.HasErrors() || attempt == attempts {
+			break
+		}
+
+		wait := actionRetryBackoff(backoff, attempt, minInterval, maxInterval)
+		hookActionInvoke(ctx, func(h ActionInvocationHook) (HookAction, error) {
+			return h.ProgressInvokeAction(n.ActionTarget, n.Schema, time.Since(start), ActionInvocationProgress{
+				Message: fmt.Sprintf("attempt %d of %d failed, retrying in %s", attempt, attempts, wait),
+			})
 		})
+
+		select {
+		case <-time.After(wait):
+		case <-invokeCtx.Done():
+		}
 	}
+	elapsed := time.Since(start)
+
+	failureDiags := resp.Diagnostics.InConfigBody(n.Config.Config, n.ActionTarget.ContainingAction().String())
+	if resp.Diagnostics.HasErrors() {
+		if onFailure == configs.ActionOnFailureIgnore {
+			// Ignored failures are discarded entirely: no error hook, no
+			// diagnostics, and no record of the invocation in the plan, as
+			// if it had never been attempted.
+			return diags
+		}
 
-	resp := provider.PlanAction(providers.PlanActionRequest{
-		ActionType:         n.ActionTarget.Action.Action.Type,
-		ProposedActionData: unmarkedConfig,
-		ClientCapabilities: ctx.ClientCapabilities(),
-	})
+		hookActionInvoke(ctx, func(h ActionInvocationHook) (HookAction, error) {
+			return h.ErrorInvokeAction(n.ActionTarget, n.Schema, resp.Diagnostics.Err())
+		})
+
+		diags = diags.Append(actionInvocationFailureDiags(onFailure, n.Target.String(), failureDiags))
+
+		if onFailure == configs.ActionOnFailureFail {
+			return diags
+		}
+
+		ai.RetryStatus = &plans.ActionInvocationRetryStatus{Attempts: attempt, Outcome: plans.ActionInvocationOutcomeFailed}
+		ctx.Changes().AppendActionInvocation(&ai)
+		return diags
+	}
+	diags = diags.Append(failureDiags)
 
-	diags = diags.Append(resp.Diagnostics.InConfigBody(n.Config.Config, n.ActionTarget.ContainingAction().String()))
 	if resp.Deferred != nil {
-		return diags.Append(&hcl.Diagnostic{
-			Severity: hcl.DiagError,
-			Summary:  "Provider deferred an action",
-			Detail:   fmt.Sprintf("The provider for %s ordered the action deferred. This likely means you are executing the action against a configuration that hasn't been completely applied.", n.Target),
-			Subject:  n.Config.DeclRange.Ptr(),
+		deferrals := actionInvocationDeferralsFor(ctx)
+		if !deferrals.DeferralAllowed() {
+			err := fmt.Errorf("the provider for %s ordered the action deferred but -invoke-allow-deferred was not set", n.Target)
+			hookActionInvoke(ctx, func(h ActionInvocationHook) (HookAction, error) {
+				return h.ErrorInvokeAction(n.ActionTarget, n.Schema, err)
+			})
+			return diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Provider deferred an action",
+				Detail:   fmt.Sprintf("The provider for %s ordered the action deferred. This likely means you are executing the action against a configuration that hasn't been completely applied. Use -invoke-allow-deferred to allow Terraform to retry this action invocation on a later plan/apply.", n.Target),
+				Subject:  n.Config.DeclRange.Ptr(),
+			})
+		}
+
+		deferrals.ReportActionInvocationDeferred(&ai, plans.DeferredActionInvocationReasonDeferredProvider)
+		hookActionInvoke(ctx, func(h ActionInvocationHook) (HookAction, error) {
+			return h.ProgressInvokeAction(n.ActionTarget, n.Schema, elapsed, ActionInvocationProgress{Message: "deferred by provider"})
 		})
+		return diags
 	}
 
+	ai.RetryStatus = &plans.ActionInvocationRetryStatus{Attempts: attempt, Outcome: plans.ActionInvocationOutcomeSucceeded}
 	ctx.Changes().AppendActionInvocation(&ai)
+
+	hookActionInvoke(ctx, func(h ActionInvocationHook) (HookAction, error) {
+		return h.PostInvokeAction(n.ActionTarget, n.Schema, &ai)
+	})
+
 	return diags
 }
+
+// actionInvocationFailureDiags applies an action's on_failure policy to the
+// diagnostics produced by a failed invocation attempt: "fail" (the
+// default) passes the failure through unchanged, "continue" downgrades it
+// to a warning so the rest of the graph walk proceeds, and "ignore" drops
+// it entirely, as if the invocation had not been attempted.
+func actionInvocationFailureDiags(onFailure configs.ActionOnFailure, targetDesc string, failureDiags tfdiags.Diagnostics) tfdiags.Diagnostics {
+	switch onFailure {
+	case configs.ActionOnFailureIgnore:
+		return nil
+	case configs.ActionOnFailureContinue:
+		var diags tfdiags.Diagnostics
+		return diags.Append(tfdiags.Sourceless(
+			tfdiags.Warning,
+			"Action invocation failed",
+			fmt.Sprintf("The invocation of %s failed, but on_failure = \"continue\" allows the plan/apply to proceed: %s", targetDesc, failureDiags.Err()),
+		))
+	default:
+		return failureDiags
+	}
+}
+
+// actionRetryBackoff computes how long to wait before the given retry
+// attempt (1-indexed) according to the action's backoff strategy, with
+// jitter applied to avoid every failed instance in a fan-out retrying in
+// lockstep. The result never exceeds the computed interval, and so never
+// exceeds maxInterval.
+func actionRetryBackoff(backoff configs.ActionRetryBackoff, attempt int, minInterval, maxInterval time.Duration) time.Duration {
+	wait := minInterval
+	if backoff == configs.ActionRetryBackoffExponential {
+		wait = minInterval * time.Duration(1<<uint(attempt-1))
+	}
+	if maxInterval > 0 && wait > maxInterval {
+		wait = maxInterval
+	}
+	if wait <= 0 {
+		return 0
+	}
+
+	// Jitter downward by up to 50% of the computed interval so that many
+	// instances retrying after the same failure don't all hammer the provider
+	// at once.
+	jitterRange := int64(wait) / 2
+	return wait - time.Duration(rand.Int63n(jitterRange+1))
+}
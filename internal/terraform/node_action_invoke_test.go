@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package terraform
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/internal/configs"
+	"github.com/hashicorp/terraform/internal/tfdiags"
+)
+
+func TestActionRetryBackoff(t *testing.T) {
+	tests := map[string]struct {
+		backoff     configs.ActionRetryBackoff
+		attempt     int
+		minInterval time.Duration
+		maxInterval time.Duration
+		wantAtLeast time.Duration
+		wantAtMost  time.Duration
+	}{
+		"fixed stays constant": {
+			backoff:     configs.ActionRetryBackoffFixed,
+			attempt:     3,
+			minInterval: 2 * time.Second,
+			maxInterval: 30 * time.Second,
+			wantAtLeast: 1 * time.Second,
+			wantAtMost:  2 * time.Second,
+		},
+		"exponential grows with attempt": {
+			backoff:     configs.ActionRetryBackoffExponential,
+			attempt:     3,
+			minInterval: 1 * time.Second,
+			maxInterval: 30 * time.Second,
+			wantAtLeast: 2 * time.Second,
+			wantAtMost:  4 * time.Second,
+		},
+		"exponential is capped by max interval": {
+			backoff:     configs.ActionRetryBackoffExponential,
+			attempt:     10,
+			minInterval: 1 * time.Second,
+			maxInterval: 5 * time.Second,
+			wantAtLeast: 2500 * time.Millisecond,
+			wantAtMost:  5 * time.Second,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := actionRetryBackoff(test.backoff, test.attempt, test.minInterval, test.maxInterval)
+			if got < test.wantAtLeast || got > test.wantAtMost {
+				t.Errorf("actionRetryBackoff(%s, %d, %s, %s) = %s; want between %s and %s",
+					test.backoff, test.attempt, test.minInterval, test.maxInterval, got, test.wantAtLeast, test.wantAtMost)
+			}
+		})
+	}
+}
+
+func TestActionRetryBackoff_zeroInterval(t *testing.T) {
+	if got := actionRetryBackoff(configs.ActionRetryBackoffFixed, 1, 0, 0); got != 0 {
+		t.Errorf("expected zero wait for a zero interval, got %s", got)
+	}
+}
+
+func TestActionInvocationFailureDiags(t *testing.T) {
+	var failureDiags tfdiags.Diagnostics
+	failureDiags = failureDiags.Append(tfdiags.Sourceless(tfdiags.Error, "boom", "it broke"))
+
+	t.Run("fail passes the error through", func(t *testing.T) {
+		got := actionInvocationFailureDiags(configs.ActionOnFailureFail, "action.foo.bar", failureDiags)
+		if !got.HasErrors() {
+			t.Fatal("expected on_failure = fail to keep the error diagnostic")
+		}
+	})
+
+	t.Run("continue downgrades to a warning", func(t *testing.T) {
+		got := actionInvocationFailureDiags(configs.ActionOnFailureContinue, "action.foo.bar", failureDiags)
+		if got.HasErrors() {
+			t.Fatal("expected on_failure = continue not to produce any error diagnostics")
+		}
+		if len(got) != 1 || got[0].Severity() != tfdiags.Warning {
+			t.Fatalf("expected exactly one warning diagnostic, got %#v", got)
+		}
+	})
+
+	t.Run("ignore drops the failure entirely", func(t *testing.T) {
+		got := actionInvocationFailureDiags(configs.ActionOnFailureIgnore, "action.foo.bar", failureDiags)
+		if len(got) != 0 {
+			t.Fatalf("expected on_failure = ignore to produce no diagnostics, got %#v", got)
+		}
+	})
+}